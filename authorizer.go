@@ -48,40 +48,320 @@ package authz
 // policies.
 type Effector[T any, T2 any] func(T, T2) bool
 
+// DetailedEffector is like an Effector, but additionally returns a slice of
+// human-readable reasons for the decision. Reasons are most useful on denial,
+// where they can be surfaced to a caller as "user is not an admin" style
+// messages, but a DetailedEffector may also return reasons alongside a true
+// result.
+type DetailedEffector[T any, T2 any] func(T, T2) (bool, []string)
+
+// SQLEffector is an Effector that can also compile itself into a SQL WHERE
+// fragment, letting [Authorizer.Compile] translate a policy for an action
+// into a fragment a database driver can embed in a query, instead of
+// loading every candidate resource and filtering them in Go via
+// [Authorizer.Filter]. resourceAlias, if non-empty, is the table alias the
+// fragment's columns should be qualified with.
+type SQLEffector[T any, T2 any] interface {
+	Enforce(subject T, resource T2) bool
+	ToSQL(resourceAlias string, subject T) (sql string, args []any, err error)
+}
+
+// defaultMode controls how an Authorizer resolves actions with no
+// registered policy. The zero value, defaultPanic, preserves the historical
+// behavior.
+type defaultMode int
+
+const (
+	defaultPanic defaultMode = iota
+	defaultDeny
+	defaultAllow
+)
+
 // Authorizer is a collection of Effectors for a pair of given types and their
 // actions.
 type Authorizer[T any, T2 any] struct {
 	Policies map[string]Effector[T, T2]
+
+	detailed map[string]DetailedEffector[T, T2]
+	sql      map[string]SQLEffector[T, T2]
+	mode     defaultMode
 }
 
 // NewAuthorizer instantiates a new Authorizer for the given types.
 func NewAuthorizer[T any, T2 any]() *Authorizer[T, T2] {
 	return &Authorizer[T, T2]{
 		Policies: map[string]Effector[T, T2]{},
+		detailed: map[string]DetailedEffector[T, T2]{},
+		sql:      map[string]SQLEffector[T, T2]{},
 	}
 }
 
+// DefaultDeny configures the Authorizer so that actions with no registered
+// policy are denied instead of causing a panic. This is the safer choice
+// when using an Authorizer to guard an HTTP server, where the set of
+// actions may grow over time.
+func (a *Authorizer[T, T2]) DefaultDeny() {
+	a.mode = defaultDeny
+}
+
+// DefaultAllow configures the Authorizer so that actions with no registered
+// policy are permitted instead of causing a panic.
+func (a *Authorizer[T, T2]) DefaultAllow() {
+	a.mode = defaultAllow
+}
+
 // AddPolicy associates an Effector with an action. If an Effector already
 // exists for a given action then AddPolicy will panic.
 func (a *Authorizer[T, T2]) AddPolicy(
 	action string,
 	effect Effector[T, T2],
 ) {
+	if err := a.AddPolicyE(action, effect); err != nil {
+		panic(err.Error())
+	}
+}
+
+// AddPolicyE is like AddPolicy, but returns an ErrPolicyExists instead of
+// panicking when a policy already exists for action.
+func (a *Authorizer[T, T2]) AddPolicyE(
+	action string,
+	effect Effector[T, T2],
+) error {
 	if _, ok := a.Policies[action]; ok {
-		panic("a policy already exists for action " + action)
+		return ErrPolicyExists{Action: action}
 	}
 
 	a.Policies[action] = effect
+
+	return nil
+}
+
+// SetPolicy associates an Effector with an action, overwriting any policy
+// already registered for that action.
+func (a *Authorizer[T, T2]) SetPolicy(
+	action string,
+	effect Effector[T, T2],
+) {
+	a.Policies[action] = effect
+	delete(a.detailed, action)
+	delete(a.sql, action)
+}
+
+// RemovePolicy removes the policy registered for action, if any. It is a
+// no-op if no policy exists for action.
+func (a *Authorizer[T, T2]) RemovePolicy(action string) {
+	delete(a.Policies, action)
+	delete(a.detailed, action)
+	delete(a.sql, action)
+}
+
+// AddSQLPolicy associates a SQLEffector with an action, registering it both
+// for row-by-row evaluation via [Authorizer.Enforce] and [Authorizer.Filter]
+// and for WHERE-clause compilation via [Authorizer.Compile]. If a policy
+// already exists for action then AddSQLPolicy will panic.
+//
+// effect must itself be a SQLEffector throughout: building one from
+// rule.All/rule.Any/rule.Not, or from any other closure-based
+// [authz.Effector], loses ToSQL and makes the whole policy opaque to
+// Compile even though Enforce still works. Use the SQL-compilable
+// combinators ([rule.AllColumns], [rule.AnyColumns], [rule.NotColumn]) and
+// rules ([rule.AttrColumn], [rule.SameOwnerColumn], [rule.ConstColumn])
+// throughout instead, or accept that the policy can only be enforced
+// row-by-row via Filter.
+func (a *Authorizer[T, T2]) AddSQLPolicy(action string, effect SQLEffector[T, T2]) {
+	a.AddPolicy(action, effect.Enforce)
+	a.sql[action] = effect
+}
+
+// Compile translates the policy registered for action into a SQL WHERE
+// fragment and its positional arguments, given a specific subject,
+// letting a list endpoint filter rows in the database instead of loading
+// every row and calling Filter in Go. resourceAlias, if non-empty, qualifies
+// the fragment's columns (e.g. "r" produces "r.owner = ?"). Use [rule.All],
+// [rule.Any], and [rule.Not]'s SQL-compilable counterparts ([rule.AllColumns],
+// [rule.AnyColumns], [rule.NotColumn]) to compile a policy built from more
+// than one predicate.
+//
+// Compile only works for policies added via [Authorizer.AddSQLPolicy], and
+// only when every predicate composing that policy is itself SQL-compilable
+// (see the note on [Authorizer.AddSQLPolicy]); for opaque closure-based
+// policies added via [Authorizer.AddPolicy], or a SQL-compilable policy
+// built with a non-compilable combinator, Compile returns an
+// ErrNotCompilable and callers should fall back to Filter.
+//
+// CompileQuery and CompileSquirrel wrap Compile for use with database/sql
+// and squirrel-style builders respectively; call Compile directly for any
+// other consumer of a (sql, args) WHERE fragment.
+func (a *Authorizer[T, T2]) Compile(resourceAlias string, subject T, action string) (string, []any, error) {
+	effect, ok := a.sql[action]
+	if !ok {
+		return "", nil, ErrNotCompilable{Action: action}
+	}
+
+	return effect.ToSQL(resourceAlias, subject)
+}
+
+// CompileQuery is like Compile, but appends the resulting WHERE fragment to
+// baseQuery (a SELECT statement with no WHERE clause of its own), returning
+// a complete query and its positional arguments ready to pass directly to
+// database/sql's *sql.DB.Query/QueryContext.
+func (a *Authorizer[T, T2]) CompileQuery(resourceAlias string, subject T, action, baseQuery string) (string, []any, error) {
+	sql, args, err := a.Compile(resourceAlias, subject, action)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return baseQuery + " WHERE " + sql, args, nil
+}
+
+// SquirrelSqlizer adapts a compiled WHERE fragment to the interface
+// squirrel-style query builders expect from their Where method
+// (ToSql() (string, []interface{}, error)), without this package needing to
+// import squirrel itself.
+type SquirrelSqlizer struct {
+	sql  string
+	args []any
+}
+
+// ToSql implements squirrel's Sqlizer interface.
+func (s SquirrelSqlizer) ToSql() (string, []interface{}, error) {
+	return s.sql, s.args, nil
+}
+
+// CompileSquirrel is like Compile, but wraps the resulting WHERE fragment in
+// a SquirrelSqlizer, for passing directly to a squirrel query builder's
+// Where method, e.g. squirrel.Select("*").From("resources").Where(sqlizer).
+func (a *Authorizer[T, T2]) CompileSquirrel(resourceAlias string, subject T, action string) (SquirrelSqlizer, error) {
+	sql, args, err := a.Compile(resourceAlias, subject, action)
+	if err != nil {
+		return SquirrelSqlizer{}, err
+	}
+
+	return SquirrelSqlizer{sql: sql, args: args}, nil
+}
+
+// AddConditionalPolicy associates an Effector with an action that only
+// grants access when both when and then permit it, e.g. to express a
+// role-gated ABAC check such as "Archivist AND resource older than 30 days"
+// without branching Go code. It panics under the same conditions as
+// AddPolicy.
+func (a *Authorizer[T, T2]) AddConditionalPolicy(
+	action string,
+	when Effector[T, T2],
+	then Effector[T, T2],
+) {
+	a.AddPolicy(action, func(subject T, resource T2) bool {
+		return when(subject, resource) && then(subject, resource)
+	})
+}
+
+// AddDetailedPolicy associates a DetailedEffector with an action. If a policy
+// already exists for a given action then AddDetailedPolicy will panic.
+//
+// The action remains usable with [Authorizer.Enforce] and [Authorizer.Filter]
+// like any other policy; its reasons are only available through
+// [Authorizer.EnforceWithReason].
+func (a *Authorizer[T, T2]) AddDetailedPolicy(
+	action string,
+	effect DetailedEffector[T, T2],
+) {
+	if _, ok := a.Policies[action]; ok {
+		panic("a policy already exists for action " + action)
+	}
+
+	a.detailed[action] = effect
+	a.Policies[action] = func(subject T, resource T2) bool {
+		ok, _ := effect(subject, resource)
+		return ok
+	}
+}
+
+// resolve looks up the Effector for action, falling back to a's default
+// mode when no policy is registered. Namespaced actions (e.g.
+// "resource:delete") also fall back to a wildcard policy for the namespace
+// (e.g. "resource:*") before the default mode applies, mirroring
+// Kubernetes' VerbAll.
+func (a *Authorizer[T, T2]) resolve(action string) (Effector[T, T2], error) {
+	if fn, ok := a.Policies[action]; ok {
+		return fn, nil
+	}
+
+	if wildcard, ok := wildcardAction(action); ok {
+		if fn, ok := a.Policies[wildcard]; ok {
+			return fn, nil
+		}
+	}
+
+	switch a.mode {
+	case defaultDeny:
+		return func(T, T2) bool { return false }, nil
+	case defaultAllow:
+		return func(T, T2) bool { return true }, nil
+	default:
+		return nil, ErrNoPolicy{Action: action}
+	}
 }
 
 // Enforce will run the Effector for a given action. If no Effector is found
-// then the appropriate policy is assumed to be missing and a panic is thrown.
+// and a has not been configured with DefaultDeny or DefaultAllow, then the
+// appropriate policy is assumed to be missing and a panic is thrown.
 func (a *Authorizer[T, T2]) Enforce(subject T, action string, resource T2) bool {
+	ok, err := a.EnforceE(subject, action, resource)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	return ok
+}
+
+// EnforceE is like Enforce, but returns an ErrNoPolicy instead of panicking
+// when no policy is registered for action and a has not been configured
+// with DefaultDeny or DefaultAllow.
+func (a *Authorizer[T, T2]) EnforceE(subject T, action string, resource T2) (bool, error) {
+	fn, err := a.resolve(action)
+	if err != nil {
+		return false, err
+	}
+
+	return fn(subject, resource), nil
+}
+
+// EnforceWithReason behaves like Enforce, but additionally returns the
+// reasons contributed by a DetailedEffector, if the policy for action was
+// added via [Authorizer.AddDetailedPolicy]. Policies added via [Authorizer.AddPolicy]
+// carry no reasons, so EnforceWithReason returns a nil slice for them.
+func (a *Authorizer[T, T2]) EnforceWithReason(subject T, action string, resource T2) (bool, []string) {
+	if fn, ok := a.detailed[action]; ok {
+		return fn(subject, resource)
+	}
+
 	if _, ok := a.Policies[action]; !ok {
-		panic("no policies for action " + action)
+		if wildcard, ok := wildcardAction(action); ok {
+			if fn, ok := a.detailed[wildcard]; ok {
+				return fn(subject, resource)
+			}
+		}
+	}
+
+	return a.Enforce(subject, action, resource), nil
+}
+
+// Filter returns the subset of resources for which Enforce would permit
+// subject to perform action, preserving their original order. This mirrors
+// the case of listing only the records a caller is allowed to see, rather
+// than enforcing access to a single known resource.
+func (a *Authorizer[T, T2]) Filter(subject T, action string, resources []T2) []T2 {
+	fn, err := a.resolve(action)
+	if err != nil {
+		panic(err.Error())
 	}
 
-	fn := a.Policies[action]
+	allowed := make([]T2, 0, len(resources))
+	for _, resource := range resources {
+		if fn(subject, resource) {
+			allowed = append(allowed, resource)
+		}
+	}
 
-	return fn(subject, resource)
+	return allowed
 }
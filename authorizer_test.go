@@ -1,6 +1,7 @@
 package authz_test
 
 import (
+	"errors"
 	"slices"
 	"testing"
 	"time"
@@ -82,6 +83,16 @@ func initializeUserResourceAuthorizer() *authz.Authorizer[User, Resource] {
 		return false
 	})
 
+	authUserResource.AddDetailedPolicy("update", func(user User, resource Resource) (bool, []string) {
+		if user.IsAdmin {
+			return true, []string{"user is an admin"}
+		}
+		if user.UserID == resource.Owner {
+			return true, []string{"user owns the resource"}
+		}
+		return false, []string{"user is not an admin", "user does not own the resource"}
+	})
+
 	return authUserResource
 }
 
@@ -228,3 +239,154 @@ func TestUserResourceAuthorizer(t *testing.T) {
 		})
 	}
 }
+
+func TestFilter(t *testing.T) {
+	auth := initializeUserResourceAuthorizer()
+
+	user := User{UserID: "user1"}
+	resources := []Resource{
+		{Owner: "user1"},
+		{Owner: "user2"},
+		{Owner: "user1"},
+		{Owner: "user3"},
+	}
+
+	actual := auth.Filter(user, "delete", resources)
+	expected := []Resource{resources[0], resources[2]}
+
+	if !slices.Equal(actual, expected) {
+		t.Errorf("Filter(%#v, delete, %#v) got %#v, want %#v", user, resources, actual, expected)
+	}
+}
+
+func TestEnforceWithReason(t *testing.T) {
+	auth := initializeUserResourceAuthorizer()
+
+	tests := []struct {
+		name            string
+		user            User
+		resource        Resource
+		expected        bool
+		expectedReasons []string
+	}{
+		{
+			name:            "admin update is allowed with a reason",
+			user:            User{UserID: "user1", IsAdmin: true},
+			resource:        Resource{Owner: "user2"},
+			expected:        true,
+			expectedReasons: []string{"user is an admin"},
+		},
+		{
+			name:            "non-owner non-admin update is denied with reasons",
+			user:            User{UserID: "user1"},
+			resource:        Resource{Owner: "user2"},
+			expected:        false,
+			expectedReasons: []string{"user is not an admin", "user does not own the resource"},
+		},
+		{
+			name:            "plain policies carry no reasons",
+			user:            User{UserID: "user1"},
+			resource:        Resource{Owner: "user1"},
+			expected:        true,
+			expectedReasons: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action := "update"
+			if tt.expectedReasons == nil {
+				action = "delete"
+			}
+
+			actual, reasons := auth.EnforceWithReason(tt.user, action, tt.resource)
+			if actual != tt.expected {
+				t.Errorf(
+					"EnforceWithReason(%#v, %s, %#v) got %v, want %v",
+					tt.user, action, tt.resource, actual, tt.expected,
+				)
+			}
+			if !slices.Equal(reasons, tt.expectedReasons) {
+				t.Errorf(
+					"EnforceWithReason(%#v, %s, %#v) got reasons %#v, want %#v",
+					tt.user, action, tt.resource, reasons, tt.expectedReasons,
+				)
+			}
+		})
+	}
+}
+
+func TestAddPolicyE(t *testing.T) {
+	auth := authz.NewAuthorizer[User, Resource]()
+
+	if err := auth.AddPolicyE("delete", func(User, Resource) bool { return true }); err != nil {
+		t.Fatalf("AddPolicyE() got unexpected error %v", err)
+	}
+
+	err := auth.AddPolicyE("delete", func(User, Resource) bool { return false })
+	var target authz.ErrPolicyExists
+	if !errors.As(err, &target) {
+		t.Fatalf("AddPolicyE() on a duplicate action got %v, want an ErrPolicyExists", err)
+	}
+}
+
+func TestEnforceE(t *testing.T) {
+	auth := authz.NewAuthorizer[User, Resource]()
+	auth.AddPolicy("delete", func(user User, resource Resource) bool { return user.IsAdmin })
+
+	ok, err := auth.EnforceE(User{IsAdmin: true}, "delete", Resource{})
+	if err != nil || !ok {
+		t.Fatalf("EnforceE() on a known action got (%v, %v), want (true, nil)", ok, err)
+	}
+
+	_, err = auth.EnforceE(User{}, "archive", Resource{})
+	var target authz.ErrNoPolicy
+	if !errors.As(err, &target) {
+		t.Fatalf("EnforceE() on an unknown action got %v, want an ErrNoPolicy", err)
+	}
+}
+
+func TestSetPolicyAndRemovePolicy(t *testing.T) {
+	auth := authz.NewAuthorizer[User, Resource]()
+	auth.AddPolicy("delete", func(User, Resource) bool { return false })
+
+	auth.SetPolicy("delete", func(User, Resource) bool { return true })
+	if !auth.Enforce(User{}, "delete", Resource{}) {
+		t.Error("SetPolicy() did not overwrite the existing policy")
+	}
+
+	auth.RemovePolicy("delete")
+	if _, err := auth.EnforceE(User{}, "delete", Resource{}); err == nil {
+		t.Error("EnforceE() after RemovePolicy() expected an error, got nil")
+	}
+}
+
+func TestDefaultDenyAndDefaultAllow(t *testing.T) {
+	denyAuth := authz.NewAuthorizer[User, Resource]()
+	denyAuth.DefaultDeny()
+	if denyAuth.Enforce(User{}, "archive", Resource{}) {
+		t.Error("Enforce() on an unknown action under DefaultDeny expected false")
+	}
+
+	allowAuth := authz.NewAuthorizer[User, Resource]()
+	allowAuth.DefaultAllow()
+	if !allowAuth.Enforce(User{}, "archive", Resource{}) {
+		t.Error("Enforce() on an unknown action under DefaultAllow expected true")
+	}
+}
+
+func TestWildcardActions(t *testing.T) {
+	auth := authz.NewAuthorizer[User, Resource]()
+	auth.AddPolicy("resource:*", func(user User, _ Resource) bool { return user.IsAdmin })
+	auth.AddPolicy("resource:update", func(user User, _ Resource) bool { return true })
+
+	if !auth.Enforce(User{IsAdmin: true}, "resource:delete", Resource{}) {
+		t.Error("expected admin to match the resource:* wildcard policy")
+	}
+	if auth.Enforce(User{}, "resource:delete", Resource{}) {
+		t.Error("expected non-admin to be denied by the resource:* wildcard policy")
+	}
+	if !auth.Enforce(User{}, "resource:update", Resource{}) {
+		t.Error("expected an exact policy match to take priority over the wildcard")
+	}
+}
@@ -0,0 +1,46 @@
+package authz
+
+import "reflect"
+
+// ErrNoPolicy is returned by EnforceE when no policy has been registered for
+// the requested action and the Authorizer has not been configured with
+// DefaultDeny or DefaultAllow.
+type ErrNoPolicy struct {
+	Action string
+}
+
+func (e ErrNoPolicy) Error() string {
+	return "no policy for action " + e.Action
+}
+
+// ErrPolicyExists is returned by AddPolicyE when a policy has already been
+// registered for the requested action.
+type ErrPolicyExists struct {
+	Action string
+}
+
+func (e ErrPolicyExists) Error() string {
+	return "a policy already exists for action " + e.Action
+}
+
+// ErrNoAuthorizer is returned by Enforce when no Authorizer has been
+// registered in a Registry for the given subject and resource types.
+type ErrNoAuthorizer struct {
+	Subject  reflect.Type
+	Resource reflect.Type
+}
+
+func (e ErrNoAuthorizer) Error() string {
+	return "no authorizer registered for " + e.Subject.String() + "/" + e.Resource.String()
+}
+
+// ErrNotCompilable is returned by Compile when the policy registered for
+// action was added via AddPolicy rather than AddSQLPolicy, and therefore
+// has no SQL representation.
+type ErrNotCompilable struct {
+	Action string
+}
+
+func (e ErrNotCompilable) Error() string {
+	return "policy for action " + e.Action + " is not SQL-compilable"
+}
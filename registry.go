@@ -0,0 +1,76 @@
+package authz
+
+import (
+	"context"
+	"reflect"
+	"strings"
+)
+
+// Registry holds Authorizers for several subject/resource type pairs and
+// dispatches a single Enforce call to whichever Authorizer was registered
+// for the concrete types involved. This lets an application build one
+// app-wide policy object, keyed by namespaced actions like "resource:delete",
+// instead of threading many Authorizers through handlers.
+type Registry struct {
+	authorizers map[registryKey]any
+}
+
+type registryKey struct {
+	subject  reflect.Type
+	resource reflect.Type
+}
+
+// NewRegistry instantiates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		authorizers: map[registryKey]any{},
+	}
+}
+
+// Register adds a to r, keyed by its subject and resource types. If an
+// Authorizer has already been registered for that pair, Register will
+// panic.
+func Register[T any, T2 any](r *Registry, a *Authorizer[T, T2]) {
+	key := registryKeyFor[T, T2]()
+	if _, ok := r.authorizers[key]; ok {
+		panic("an authorizer is already registered for " + key.subject.String() + "/" + key.resource.String())
+	}
+
+	r.authorizers[key] = a
+}
+
+// Enforce dispatches to the Authorizer registered in r for the types of
+// subject and resource, returning an ErrNoAuthorizer if none was
+// registered. ctx is accepted but not otherwise used today; it is threaded
+// through so a future Authorizer that looks up policies or subject/resource
+// data from a database or remote service can be given one without a
+// breaking API change.
+func Enforce[T any, T2 any](ctx context.Context, r *Registry, subject T, action string, resource T2) (bool, error) {
+	key := registryKeyFor[T, T2]()
+
+	v, ok := r.authorizers[key]
+	if !ok {
+		return false, ErrNoAuthorizer{Subject: key.subject, Resource: key.resource}
+	}
+
+	return v.(*Authorizer[T, T2]).EnforceE(subject, action, resource)
+}
+
+func registryKeyFor[T any, T2 any]() registryKey {
+	return registryKey{
+		subject:  reflect.TypeOf((*T)(nil)).Elem(),
+		resource: reflect.TypeOf((*T2)(nil)).Elem(),
+	}
+}
+
+// wildcardAction returns the wildcard form of a namespaced action, e.g.
+// "resource:delete" becomes "resource:*", and reports whether action was
+// namespaced at all.
+func wildcardAction(action string) (string, bool) {
+	idx := strings.LastIndex(action, ":")
+	if idx < 0 {
+		return "", false
+	}
+
+	return action[:idx] + ":*", true
+}
@@ -0,0 +1,47 @@
+package authz_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"authz"
+)
+
+type Team struct {
+	Name string
+}
+
+func TestRegistry(t *testing.T) {
+	userResourceAuth := authz.NewAuthorizer[User, Resource]()
+	userResourceAuth.AddPolicy("resource:delete", func(user User, resource Resource) bool {
+		return user.IsAdmin || user.UserID == resource.Owner
+	})
+
+	userTeamAuth := authz.NewAuthorizer[User, Team]()
+	userTeamAuth.AddPolicy("team:rename", func(user User, _ Team) bool {
+		return user.IsAdmin
+	})
+
+	reg := authz.NewRegistry()
+	authz.Register(reg, userResourceAuth)
+	authz.Register(reg, userTeamAuth)
+
+	ctx := context.Background()
+
+	ok, err := authz.Enforce(ctx, reg, User{UserID: "user1"}, "resource:delete", Resource{Owner: "user1"})
+	if err != nil || !ok {
+		t.Fatalf("Enforce() on User/Resource got (%v, %v), want (true, nil)", ok, err)
+	}
+
+	ok, err = authz.Enforce(ctx, reg, User{IsAdmin: true}, "team:rename", Team{Name: "Core"})
+	if err != nil || !ok {
+		t.Fatalf("Enforce() on User/Team got (%v, %v), want (true, nil)", ok, err)
+	}
+
+	_, err = authz.Enforce(ctx, reg, Team{Name: "Core"}, "team:rename", Team{Name: "Core"})
+	var target authz.ErrNoAuthorizer
+	if !errors.As(err, &target) {
+		t.Fatalf("Enforce() on an unregistered type pair got %v, want an ErrNoAuthorizer", err)
+	}
+}
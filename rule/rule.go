@@ -0,0 +1,129 @@
+/*
+Package rule provides composable predicates for building [authz.Effector]
+values declaratively, as an alternative to writing bespoke closures by hand.
+
+Primitives such as [HasRole] and [Attr] each produce an Effector that checks
+a single condition. They can be combined with [All], [Any], and [Not] to
+build up more complex policies.
+
+	auth.AddPolicy("delete", rule.Any(
+		rule.HasRole[User, Resource](func(u User) []string { return u.Roles }, "Admin"),
+		rule.SameOwner(
+			func(u User) string { return u.UserID },
+			func(r Resource) string { return r.Owner },
+		),
+	))
+
+[AttrColumn], [SameOwnerColumn], and [ConstColumn] additionally compile
+themselves into a SQL WHERE fragment via ToSQL, for use with
+[authz.Authorizer.AddSQLPolicy] and [authz.Authorizer.Compile]. [AllColumns],
+[AnyColumns], and [NotColumn] are SQL-compilable counterparts to All, Any,
+and Not, for combining several such rules (e.g. "admin sees all OR owner
+sees own") into one compilable policy.
+*/
+package rule
+
+import (
+	"cmp"
+	"slices"
+
+	"authz"
+)
+
+// HasRole builds an Effector that reports whether the subject, as described
+// by roles, has the given role. It ignores the resource entirely, which
+// makes it equally useful on its own or combined with resource-aware rules
+// via All, Any, or [authz.Authorizer.AddConditionalPolicy].
+func HasRole[T any, T2 any](roles func(T) []string, role string) authz.Effector[T, T2] {
+	return func(subject T, _ T2) bool {
+		return slices.Contains(roles(subject), role)
+	}
+}
+
+// SameOwner builds an Effector that reports whether the subject and the
+// resource share the same owner identity, as reported by subjectID and
+// resourceOwner respectively.
+func SameOwner[T any, T2 any, ID comparable](subjectID func(T) ID, resourceOwner func(T2) ID) authz.Effector[T, T2] {
+	return func(subject T, resource T2) bool {
+		return subjectID(subject) == resourceOwner(resource)
+	}
+}
+
+// Op identifies the comparison performed by Attr.
+type Op int
+
+const (
+	Eq Op = iota
+	Neq
+	Lt
+	Lte
+	Gt
+	Gte
+)
+
+// Attr builds an Effector that extracts a single attribute from the
+// resource via getter and compares it against value using op. For attribute
+// types that are not ordered by Go's built-in operators (time.Time, for
+// example), use AttrFunc instead.
+func Attr[T any, T2 any, V cmp.Ordered](getter func(T2) V, op Op, value V) authz.Effector[T, T2] {
+	return AttrFunc[T, T2](getter, op, value, cmp.Compare[V])
+}
+
+// AttrFunc is like Attr, but compares the extracted attribute against value
+// using compare instead of Go's built-in operators. compare must return a
+// negative number if a < b, zero if a == b, and a positive number if a > b,
+// mirroring [cmp.Compare].
+func AttrFunc[T any, T2 any, V any](getter func(T2) V, op Op, value V, compare func(a, b V) int) authz.Effector[T, T2] {
+	return func(_ T, resource T2) bool {
+		c := compare(getter(resource), value)
+		switch op {
+		case Eq:
+			return c == 0
+		case Neq:
+			return c != 0
+		case Lt:
+			return c < 0
+		case Lte:
+			return c <= 0
+		case Gt:
+			return c > 0
+		case Gte:
+			return c >= 0
+		default:
+			return false
+		}
+	}
+}
+
+// All builds an Effector that permits access only if every one of rules
+// permits it.
+func All[T any, T2 any](rules ...authz.Effector[T, T2]) authz.Effector[T, T2] {
+	return func(subject T, resource T2) bool {
+		for _, r := range rules {
+			if !r(subject, resource) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Any builds an Effector that permits access if at least one of rules
+// permits it.
+func Any[T any, T2 any](rules ...authz.Effector[T, T2]) authz.Effector[T, T2] {
+	return func(subject T, resource T2) bool {
+		for _, r := range rules {
+			if r(subject, resource) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not builds an Effector that permits access exactly when r does not.
+func Not[T any, T2 any](r authz.Effector[T, T2]) authz.Effector[T, T2] {
+	return func(subject T, resource T2) bool {
+		return !r(subject, resource)
+	}
+}
@@ -0,0 +1,122 @@
+package rule_test
+
+import (
+	"testing"
+	"time"
+
+	"authz"
+	"authz/rule"
+)
+
+type User struct {
+	UserID  string
+	Roles   []string
+	IsAdmin bool
+}
+
+type Resource struct {
+	Owner   string
+	Created time.Time
+}
+
+func TestHasRole(t *testing.T) {
+	isAdmin := rule.HasRole[User, Resource](func(u User) []string { return u.Roles }, "Admin")
+
+	if !isAdmin(User{Roles: []string{"Admin"}}, Resource{}) {
+		t.Error("expected user with Admin role to pass HasRole")
+	}
+	if isAdmin(User{Roles: []string{"Editor"}}, Resource{}) {
+		t.Error("expected user without Admin role to fail HasRole")
+	}
+}
+
+func TestSameOwner(t *testing.T) {
+	owns := rule.SameOwner[User, Resource](
+		func(u User) string { return u.UserID },
+		func(r Resource) string { return r.Owner },
+	)
+
+	if !owns(User{UserID: "user1"}, Resource{Owner: "user1"}) {
+		t.Error("expected matching owner to pass SameOwner")
+	}
+	if owns(User{UserID: "user1"}, Resource{Owner: "user2"}) {
+		t.Error("expected mismatched owner to fail SameOwner")
+	}
+}
+
+func TestAttrFunc(t *testing.T) {
+	old := rule.AttrFunc[User, Resource](
+		func(r Resource) time.Time { return r.Created },
+		rule.Lt,
+		time.Now().Add(-time.Hour*24*30),
+		time.Time.Compare,
+	)
+
+	if !old(User{}, Resource{Created: time.Now().Add(-time.Hour * 24 * 31)}) {
+		t.Error("expected resource older than 30 days to pass AttrFunc(Lt)")
+	}
+	if old(User{}, Resource{Created: time.Now().Add(-time.Hour * 24 * 10)}) {
+		t.Error("expected resource younger than 30 days to fail AttrFunc(Lt)")
+	}
+}
+
+func TestAttr(t *testing.T) {
+	active := rule.Attr[User, Resource](func(r Resource) string { return r.Owner }, rule.Eq, "user1")
+
+	if !active(User{}, Resource{Owner: "user1"}) {
+		t.Error("expected matching owner to pass Attr(Eq)")
+	}
+	if active(User{}, Resource{Owner: "user2"}) {
+		t.Error("expected mismatched owner to fail Attr(Eq)")
+	}
+}
+
+func TestCombinators(t *testing.T) {
+	isAdmin := rule.HasRole[User, Resource](func(u User) []string { return u.Roles }, "Admin")
+	owns := rule.SameOwner[User, Resource](
+		func(u User) string { return u.UserID },
+		func(r Resource) string { return r.Owner },
+	)
+
+	all := rule.All(isAdmin, owns)
+	if all(User{UserID: "user1", Roles: []string{"Admin"}}, Resource{Owner: "user2"}) {
+		t.Error("expected All to fail when one rule fails")
+	}
+	if !all(User{UserID: "user1", Roles: []string{"Admin"}}, Resource{Owner: "user1"}) {
+		t.Error("expected All to pass when every rule passes")
+	}
+
+	any := rule.Any(isAdmin, owns)
+	if !any(User{UserID: "user1"}, Resource{Owner: "user1"}) {
+		t.Error("expected Any to pass when at least one rule passes")
+	}
+	if any(User{UserID: "user1"}, Resource{Owner: "user2"}) {
+		t.Error("expected Any to fail when no rule passes")
+	}
+
+	not := rule.Not(owns)
+	if not(User{UserID: "user1"}, Resource{Owner: "user1"}) {
+		t.Error("expected Not to invert owns")
+	}
+}
+
+func TestAddConditionalPolicy(t *testing.T) {
+	auth := authz.NewAuthorizer[User, Resource]()
+
+	auth.AddConditionalPolicy(
+		"delete",
+		rule.HasRole[User, Resource](func(u User) []string { return u.Roles }, "Archivist"),
+		rule.AttrFunc[User, Resource](func(r Resource) time.Time { return r.Created }, rule.Lt, time.Now().Add(-time.Hour*24*30), time.Time.Compare),
+	)
+
+	archivist := User{Roles: []string{"Archivist"}}
+	if !auth.Enforce(archivist, "delete", Resource{Created: time.Now().Add(-time.Hour * 24 * 31)}) {
+		t.Error("expected Archivist to delete a resource older than 30 days")
+	}
+	if auth.Enforce(archivist, "delete", Resource{Created: time.Now().Add(-time.Hour * 24 * 10)}) {
+		t.Error("expected Archivist to be denied on a resource younger than 30 days")
+	}
+	if auth.Enforce(User{}, "delete", Resource{Created: time.Now().Add(-time.Hour * 24 * 31)}) {
+		t.Error("expected non-Archivist to be denied regardless of resource age")
+	}
+}
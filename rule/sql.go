@@ -0,0 +1,209 @@
+package rule
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+
+	"authz"
+)
+
+var sqlOps = map[Op]string{
+	Eq:  "=",
+	Neq: "<>",
+	Lt:  "<",
+	Lte: "<=",
+	Gt:  ">",
+	Gte: ">=",
+}
+
+func qualify(resourceAlias, column string) string {
+	if resourceAlias == "" {
+		return column
+	}
+
+	return resourceAlias + "." + column
+}
+
+type attrColumn[T any, T2 any, V cmp.Ordered] struct {
+	column string
+	getter func(T2) V
+	op     Op
+	value  V
+}
+
+// AttrColumn is like Attr, but additionally knows how to compile itself into
+// a SQL WHERE fragment via ToSQL, for use with [authz.Authorizer.AddSQLPolicy]
+// and [authz.Authorizer.Compile]. column is the name of the database column
+// backing the attribute that getter reads in Go.
+func AttrColumn[T any, T2 any, V cmp.Ordered](column string, getter func(T2) V, op Op, value V) authz.SQLEffector[T, T2] {
+	return attrColumn[T, T2, V]{column: column, getter: getter, op: op, value: value}
+}
+
+func (p attrColumn[T, T2, V]) Enforce(subject T, resource T2) bool {
+	return Attr[T, T2](p.getter, p.op, p.value)(subject, resource)
+}
+
+func (p attrColumn[T, T2, V]) ToSQL(resourceAlias string, _ T) (string, []any, error) {
+	opSQL, ok := sqlOps[p.op]
+	if !ok {
+		return "", nil, fmt.Errorf("rule: op %d has no SQL equivalent", p.op)
+	}
+
+	return qualify(resourceAlias, p.column) + " " + opSQL + " ?", []any{p.value}, nil
+}
+
+type sameOwnerColumn[T any, T2 any, ID comparable] struct {
+	column        string
+	subjectID     func(T) ID
+	resourceOwner func(T2) ID
+}
+
+// SameOwnerColumn is like SameOwner, but additionally knows how to compile
+// itself into a SQL WHERE fragment via ToSQL, for use with
+// [authz.Authorizer.AddSQLPolicy] and [authz.Authorizer.Compile]. column is
+// the name of the database column backing the owner identity that
+// resourceOwner reads in Go.
+func SameOwnerColumn[T any, T2 any, ID comparable](column string, subjectID func(T) ID, resourceOwner func(T2) ID) authz.SQLEffector[T, T2] {
+	return sameOwnerColumn[T, T2, ID]{column: column, subjectID: subjectID, resourceOwner: resourceOwner}
+}
+
+func (p sameOwnerColumn[T, T2, ID]) Enforce(subject T, resource T2) bool {
+	return SameOwner[T, T2](p.subjectID, p.resourceOwner)(subject, resource)
+}
+
+func (p sameOwnerColumn[T, T2, ID]) ToSQL(resourceAlias string, subject T) (string, []any, error) {
+	return qualify(resourceAlias, p.column) + " = ?", []any{p.subjectID(subject)}, nil
+}
+
+type constColumn[T any, T2 any] struct {
+	fn func(T) bool
+}
+
+// ConstColumn is a SQL-compilable rule for a subject-only predicate (a role
+// or permission check, for example) that has no backing resource column.
+// It compiles to a constant TRUE or FALSE fragment depending on fn(subject),
+// so it can be combined with resource-column rules like AttrColumn and
+// SameOwnerColumn via AllColumns/AnyColumns/NotColumn, e.g. to express
+// "admin sees all OR owner sees own" as a single compilable policy.
+func ConstColumn[T any, T2 any](fn func(T) bool) authz.SQLEffector[T, T2] {
+	return constColumn[T, T2]{fn: fn}
+}
+
+func (p constColumn[T, T2]) Enforce(subject T, _ T2) bool {
+	return p.fn(subject)
+}
+
+func (p constColumn[T, T2]) ToSQL(_ string, subject T) (string, []any, error) {
+	if p.fn(subject) {
+		return "TRUE", nil, nil
+	}
+
+	return "FALSE", nil, nil
+}
+
+type allColumns[T any, T2 any] struct {
+	rules []authz.SQLEffector[T, T2]
+}
+
+// AllColumns is like All, but for SQL-compilable rules: it also knows how
+// to compile itself into a SQL WHERE fragment via ToSQL, by joining each
+// rule's own fragment with AND. This is what makes a conjunction of rules
+// such as AttrColumn(...) and SameOwnerColumn(...) usable with
+// [authz.Authorizer.AddSQLPolicy] and [authz.Authorizer.Compile].
+func AllColumns[T any, T2 any](rules ...authz.SQLEffector[T, T2]) authz.SQLEffector[T, T2] {
+	return allColumns[T, T2]{rules: rules}
+}
+
+func (p allColumns[T, T2]) Enforce(subject T, resource T2) bool {
+	for _, r := range p.rules {
+		if !r.Enforce(subject, resource) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p allColumns[T, T2]) ToSQL(resourceAlias string, subject T) (string, []any, error) {
+	return joinSQL(p.rules, "AND", resourceAlias, subject)
+}
+
+type anyColumns[T any, T2 any] struct {
+	rules []authz.SQLEffector[T, T2]
+}
+
+// AnyColumns is like Any, but for SQL-compilable rules: it also knows how
+// to compile itself into a SQL WHERE fragment via ToSQL, by joining each
+// rule's own fragment with OR. This is the combinator behind the canonical
+// list-endpoint policy "admin sees all OR owner sees own":
+//
+//	auth.AddSQLPolicy("list", rule.AnyColumns[User, Resource](
+//		rule.ConstColumn[User, Resource](func(u User) bool { return u.IsAdmin }),
+//		rule.SameOwnerColumn[User, Resource](
+//			"owner",
+//			func(u User) string { return u.UserID },
+//			func(r Resource) string { return r.Owner },
+//		),
+//	))
+func AnyColumns[T any, T2 any](rules ...authz.SQLEffector[T, T2]) authz.SQLEffector[T, T2] {
+	return anyColumns[T, T2]{rules: rules}
+}
+
+func (p anyColumns[T, T2]) Enforce(subject T, resource T2) bool {
+	for _, r := range p.rules {
+		if r.Enforce(subject, resource) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p anyColumns[T, T2]) ToSQL(resourceAlias string, subject T) (string, []any, error) {
+	return joinSQL(p.rules, "OR", resourceAlias, subject)
+}
+
+// joinSQL compiles each of rules and joins their fragments with sep,
+// parenthesizing each fragment so that its own operators bind before sep.
+// The joined result itself is not wrapped in parentheses.
+func joinSQL[T any, T2 any](rules []authz.SQLEffector[T, T2], sep, resourceAlias string, subject T) (string, []any, error) {
+	if len(rules) == 0 {
+		return "", nil, fmt.Errorf("rule: no rules to join with %s", sep)
+	}
+
+	parts := make([]string, 0, len(rules))
+	var args []any
+	for _, r := range rules {
+		sql, a, err := r.ToSQL(resourceAlias, subject)
+		if err != nil {
+			return "", nil, err
+		}
+		parts = append(parts, "("+sql+")")
+		args = append(args, a...)
+	}
+
+	return strings.Join(parts, " "+sep+" "), args, nil
+}
+
+type notColumn[T any, T2 any] struct {
+	rule authz.SQLEffector[T, T2]
+}
+
+// NotColumn is like Not, but for a SQL-compilable rule: it also knows how
+// to compile itself into a SQL WHERE fragment via ToSQL, by negating r's
+// own fragment.
+func NotColumn[T any, T2 any](r authz.SQLEffector[T, T2]) authz.SQLEffector[T, T2] {
+	return notColumn[T, T2]{rule: r}
+}
+
+func (p notColumn[T, T2]) Enforce(subject T, resource T2) bool {
+	return !p.rule.Enforce(subject, resource)
+}
+
+func (p notColumn[T, T2]) ToSQL(resourceAlias string, subject T) (string, []any, error) {
+	sql, args, err := p.rule.ToSQL(resourceAlias, subject)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return "NOT (" + sql + ")", args, nil
+}
@@ -0,0 +1,228 @@
+package rule_test
+
+import (
+	"errors"
+	"testing"
+
+	"authz"
+	"authz/rule"
+)
+
+func TestAttrColumn(t *testing.T) {
+	auth := authz.NewAuthorizer[User, Resource]()
+	auth.AddSQLPolicy("view", rule.AttrColumn[User, Resource](
+		"owner",
+		func(r Resource) string { return r.Owner },
+		rule.Eq,
+		"user1",
+	))
+
+	if !auth.Enforce(User{}, "view", Resource{Owner: "user1"}) {
+		t.Error("expected Enforce to evaluate the underlying Attr check")
+	}
+	if auth.Enforce(User{}, "view", Resource{Owner: "user2"}) {
+		t.Error("expected Enforce to deny a non-matching owner")
+	}
+
+	sql, args, err := auth.Compile("r", User{}, "view")
+	if err != nil {
+		t.Fatalf("Compile() got unexpected error %v", err)
+	}
+	if want := "r.owner = ?"; sql != want {
+		t.Errorf("Compile() got sql %q, want %q", sql, want)
+	}
+	if len(args) != 1 || args[0] != "user1" {
+		t.Errorf("Compile() got args %#v, want [\"user1\"]", args)
+	}
+}
+
+func TestSameOwnerColumn(t *testing.T) {
+	auth := authz.NewAuthorizer[User, Resource]()
+	auth.AddSQLPolicy("delete", rule.SameOwnerColumn[User, Resource](
+		"owner",
+		func(u User) string { return u.UserID },
+		func(r Resource) string { return r.Owner },
+	))
+
+	if !auth.Enforce(User{UserID: "user1"}, "delete", Resource{Owner: "user1"}) {
+		t.Error("expected Enforce to evaluate the underlying SameOwner check")
+	}
+
+	sql, args, err := auth.Compile("", User{UserID: "user1"}, "delete")
+	if err != nil {
+		t.Fatalf("Compile() got unexpected error %v", err)
+	}
+	if want := "owner = ?"; sql != want {
+		t.Errorf("Compile() got sql %q, want %q", sql, want)
+	}
+	if len(args) != 1 || args[0] != "user1" {
+		t.Errorf("Compile() got args %#v, want [\"user1\"]", args)
+	}
+}
+
+func TestConstColumn(t *testing.T) {
+	auth := authz.NewAuthorizer[User, Resource]()
+	auth.AddSQLPolicy("list", rule.ConstColumn[User, Resource](func(u User) bool { return u.IsAdmin }))
+
+	if !auth.Enforce(User{IsAdmin: true}, "list", Resource{}) {
+		t.Error("expected Enforce to allow an admin subject")
+	}
+	if auth.Enforce(User{}, "list", Resource{}) {
+		t.Error("expected Enforce to deny a non-admin subject")
+	}
+
+	sql, args, err := auth.Compile("", User{IsAdmin: true}, "list")
+	if err != nil {
+		t.Fatalf("Compile() got unexpected error %v", err)
+	}
+	if want := "TRUE"; sql != want {
+		t.Errorf("Compile() got sql %q, want %q", sql, want)
+	}
+	if len(args) != 0 {
+		t.Errorf("Compile() got args %#v, want none", args)
+	}
+
+	sql, _, err = auth.Compile("", User{}, "list")
+	if err != nil {
+		t.Fatalf("Compile() got unexpected error %v", err)
+	}
+	if want := "FALSE"; sql != want {
+		t.Errorf("Compile() got sql %q, want %q", sql, want)
+	}
+}
+
+func TestAnyColumns(t *testing.T) {
+	auth := authz.NewAuthorizer[User, Resource]()
+	auth.AddSQLPolicy("list", rule.AnyColumns[User, Resource](
+		rule.ConstColumn[User, Resource](func(u User) bool { return u.IsAdmin }),
+		rule.SameOwnerColumn[User, Resource](
+			"owner",
+			func(u User) string { return u.UserID },
+			func(r Resource) string { return r.Owner },
+		),
+	))
+
+	if !auth.Enforce(User{IsAdmin: true}, "list", Resource{Owner: "user2"}) {
+		t.Error("expected Enforce to allow an admin regardless of ownership")
+	}
+	if !auth.Enforce(User{UserID: "user1"}, "list", Resource{Owner: "user1"}) {
+		t.Error("expected Enforce to allow an owner-matching resource")
+	}
+	if auth.Enforce(User{UserID: "user1"}, "list", Resource{Owner: "user2"}) {
+		t.Error("expected Enforce to deny a non-admin subject that does not own the resource")
+	}
+
+	sql, args, err := auth.Compile("r", User{UserID: "user1"}, "list")
+	if err != nil {
+		t.Fatalf("Compile() got unexpected error %v", err)
+	}
+	if want := "(FALSE) OR (r.owner = ?)"; sql != want {
+		t.Errorf("Compile() got sql %q, want %q", sql, want)
+	}
+	if len(args) != 1 || args[0] != "user1" {
+		t.Errorf("Compile() got args %#v, want [\"user1\"]", args)
+	}
+}
+
+func TestAllColumns(t *testing.T) {
+	auth := authz.NewAuthorizer[User, Resource]()
+	auth.AddSQLPolicy("list", rule.AllColumns[User, Resource](
+		rule.SameOwnerColumn[User, Resource](
+			"owner",
+			func(u User) string { return u.UserID },
+			func(r Resource) string { return r.Owner },
+		),
+		rule.AttrColumn[User, Resource]("status", func(Resource) string { return "active" }, rule.Eq, "active"),
+	))
+
+	sql, _, err := auth.Compile("", User{UserID: "user1"}, "list")
+	if err != nil {
+		t.Fatalf("Compile() got unexpected error %v", err)
+	}
+	if want := "(owner = ?) AND (status = ?)"; sql != want {
+		t.Errorf("Compile() got sql %q, want %q", sql, want)
+	}
+}
+
+func TestNotColumn(t *testing.T) {
+	auth := authz.NewAuthorizer[User, Resource]()
+	auth.AddSQLPolicy("list", rule.NotColumn[User, Resource](
+		rule.AttrColumn[User, Resource]("status", func(Resource) string { return "" }, rule.Eq, "archived"),
+	))
+
+	sql, args, err := auth.Compile("", User{}, "list")
+	if err != nil {
+		t.Fatalf("Compile() got unexpected error %v", err)
+	}
+	if want := "NOT (status = ?)"; sql != want {
+		t.Errorf("Compile() got sql %q, want %q", sql, want)
+	}
+	if len(args) != 1 || args[0] != "archived" {
+		t.Errorf("Compile() got args %#v, want [\"archived\"]", args)
+	}
+}
+
+func TestCompileQuery(t *testing.T) {
+	auth := authz.NewAuthorizer[User, Resource]()
+	auth.AddSQLPolicy("view", rule.AttrColumn[User, Resource](
+		"owner",
+		func(r Resource) string { return r.Owner },
+		rule.Eq,
+		"user1",
+	))
+
+	sql, args, err := auth.CompileQuery("r", User{}, "view", "SELECT * FROM resources r")
+	if err != nil {
+		t.Fatalf("CompileQuery() got unexpected error %v", err)
+	}
+	if want := "SELECT * FROM resources r WHERE r.owner = ?"; sql != want {
+		t.Errorf("CompileQuery() got sql %q, want %q", sql, want)
+	}
+	if len(args) != 1 || args[0] != "user1" {
+		t.Errorf("CompileQuery() got args %#v, want [\"user1\"]", args)
+	}
+}
+
+func TestCompileSquirrel(t *testing.T) {
+	auth := authz.NewAuthorizer[User, Resource]()
+	auth.AddSQLPolicy("view", rule.AttrColumn[User, Resource](
+		"owner",
+		func(r Resource) string { return r.Owner },
+		rule.Eq,
+		"user1",
+	))
+
+	sqlizer, err := auth.CompileSquirrel("r", User{}, "view")
+	if err != nil {
+		t.Fatalf("CompileSquirrel() got unexpected error %v", err)
+	}
+
+	// squirrel's Sqlizer interface is exactly this method set; asserting
+	// against a local copy proves CompileSquirrel's result satisfies it
+	// without this package importing squirrel.
+	var _ interface {
+		ToSql() (string, []interface{}, error)
+	} = sqlizer
+
+	sql, args, err := sqlizer.ToSql()
+	if err != nil {
+		t.Fatalf("ToSql() got unexpected error %v", err)
+	}
+	if want := "r.owner = ?"; sql != want {
+		t.Errorf("ToSql() got sql %q, want %q", sql, want)
+	}
+	if len(args) != 1 || args[0] != "user1" {
+		t.Errorf("ToSql() got args %#v, want [\"user1\"]", args)
+	}
+}
+
+func TestCompileNotCompilable(t *testing.T) {
+	auth := authz.NewAuthorizer[User, Resource]()
+	auth.AddPolicy("delete", func(User, Resource) bool { return true })
+
+	_, _, err := auth.Compile("r", User{}, "delete")
+	var target authz.ErrNotCompilable
+	if !errors.As(err, &target) {
+		t.Fatalf("Compile() got %v, want an ErrNotCompilable", err)
+	}
+}
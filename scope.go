@@ -0,0 +1,107 @@
+package authz
+
+import "slices"
+
+// Identifier may be implemented by a resource type to give it a stable
+// identity, letting a Scope restrict access to an explicit allow-list of
+// resources.
+type Identifier interface {
+	ResourceID() string
+}
+
+// Scope restricts a subject to a set of actions and, optionally, an explicit
+// allow-list of resources. It expresses workspace/agent tokens or
+// OAuth-style scoped credentials without encoding the restriction into
+// every Effector.
+type Scope struct {
+	// Actions lists the only actions the scope permits. A nil or empty
+	// Actions permits any action. As with [Authorizer] policies, a
+	// namespaced action (e.g. "resource:delete") also matches a wildcard
+	// entry for its namespace (e.g. "resource:*").
+	Actions []string
+
+	// AllowList, if non-nil, lists the only resource IDs (per Identifier)
+	// the scope permits. Resources that do not implement Identifier are
+	// denied whenever AllowList is set.
+	AllowList []string
+}
+
+func (s Scope) allows(action string, resource any) bool {
+	if len(s.Actions) > 0 && !slices.Contains(s.Actions, action) {
+		if wildcard, ok := wildcardAction(action); !ok || !slices.Contains(s.Actions, wildcard) {
+			return false
+		}
+	}
+
+	if s.AllowList == nil {
+		return true
+	}
+
+	id, ok := resource.(Identifier)
+	if !ok {
+		return false
+	}
+
+	return slices.Contains(s.AllowList, id.ResourceID())
+}
+
+// Scoped wraps a subject with a Scope.
+type Scoped[T any] struct {
+	Subject T
+	Scope   Scope
+}
+
+// WithScope wraps subject with scope, for use with a [ScopedAuthorizer].
+func WithScope[T any](subject T, scope Scope) Scoped[T] {
+	return Scoped[T]{Subject: subject, Scope: scope}
+}
+
+// ScopedAuthorizer wraps an Authorizer so that subjects are passed as
+// Scoped[T], letting Enforce short-circuit to false when the requested
+// action is outside the subject's Scope, or the resource is not in its
+// allow-list, before ever running the underlying Effector.
+type ScopedAuthorizer[T any, T2 any] struct {
+	Authorizer *Authorizer[T, T2]
+}
+
+// NewScopedAuthorizer wraps auth for use with Scoped subjects.
+func NewScopedAuthorizer[T any, T2 any](auth *Authorizer[T, T2]) *ScopedAuthorizer[T, T2] {
+	return &ScopedAuthorizer[T, T2]{Authorizer: auth}
+}
+
+// Enforce behaves like [Authorizer.Enforce], but first checks subject's
+// Scope and returns false without consulting the wrapped Authorizer if the
+// scope denies access.
+func (a *ScopedAuthorizer[T, T2]) Enforce(subject Scoped[T], action string, resource T2) bool {
+	if !subject.Scope.allows(action, resource) {
+		return false
+	}
+
+	return a.Authorizer.Enforce(subject.Subject, action, resource)
+}
+
+// EnforceE behaves like [Authorizer.EnforceE], but first checks subject's
+// Scope and returns (false, nil) without consulting the wrapped Authorizer
+// if the scope denies access.
+func (a *ScopedAuthorizer[T, T2]) EnforceE(subject Scoped[T], action string, resource T2) (bool, error) {
+	if !subject.Scope.allows(action, resource) {
+		return false, nil
+	}
+
+	return a.Authorizer.EnforceE(subject.Subject, action, resource)
+}
+
+// Filter behaves like [Authorizer.Filter], but first narrows resources to
+// those subject's Scope allows, so a scoped listing can never surface a
+// resource outside the subject's allow-list, even when the wrapped
+// Authorizer's policy would otherwise permit it.
+func (a *ScopedAuthorizer[T, T2]) Filter(subject Scoped[T], action string, resources []T2) []T2 {
+	inScope := make([]T2, 0, len(resources))
+	for _, resource := range resources {
+		if subject.Scope.allows(action, resource) {
+			inScope = append(inScope, resource)
+		}
+	}
+
+	return a.Authorizer.Filter(subject.Subject, action, inScope)
+}
@@ -0,0 +1,103 @@
+package authz_test
+
+import (
+	"testing"
+
+	"authz"
+)
+
+type IdentifiedResource struct {
+	ID    string
+	Owner string
+}
+
+func (r IdentifiedResource) ResourceID() string {
+	return r.ID
+}
+
+func initializeScopedAuthorizer() *authz.ScopedAuthorizer[User, IdentifiedResource] {
+	auth := authz.NewAuthorizer[User, IdentifiedResource]()
+	auth.AddPolicy("read", func(User, IdentifiedResource) bool { return true })
+	auth.AddPolicy("delete", func(user User, resource IdentifiedResource) bool {
+		return user.UserID == resource.Owner
+	})
+
+	return authz.NewScopedAuthorizer(auth)
+}
+
+func TestScopedAuthorizerActions(t *testing.T) {
+	scoped := initializeScopedAuthorizer()
+	subject := authz.WithScope(User{UserID: "user1"}, authz.Scope{Actions: []string{"read"}})
+
+	if !scoped.Enforce(subject, "read", IdentifiedResource{ID: "res1", Owner: "user2"}) {
+		t.Error("expected read, which is in scope, to be permitted")
+	}
+	if scoped.Enforce(subject, "delete", IdentifiedResource{ID: "res1", Owner: "user1"}) {
+		t.Error("expected delete, which is out of scope, to be denied even though the underlying policy would allow it")
+	}
+}
+
+func TestScopedAuthorizerAllowList(t *testing.T) {
+	scoped := initializeScopedAuthorizer()
+	subject := authz.WithScope(User{UserID: "user1"}, authz.Scope{
+		Actions:   []string{"read"},
+		AllowList: []string{"res1"},
+	})
+
+	if !scoped.Enforce(subject, "read", IdentifiedResource{ID: "res1", Owner: "user2"}) {
+		t.Error("expected a resource on the allow-list to be permitted")
+	}
+	if scoped.Enforce(subject, "read", IdentifiedResource{ID: "res2", Owner: "user2"}) {
+		t.Error("expected a resource not on the allow-list to be denied")
+	}
+}
+
+func TestScopedAuthorizerUnidentifiedResource(t *testing.T) {
+	auth := authz.NewAuthorizer[User, Resource]()
+	auth.AddPolicy("read", func(User, Resource) bool { return true })
+	scoped := authz.NewScopedAuthorizer(auth)
+
+	subject := authz.WithScope(User{UserID: "user1"}, authz.Scope{AllowList: []string{"res1"}})
+
+	if scoped.Enforce(subject, "read", Resource{Owner: "user1"}) {
+		t.Error("expected a resource that does not implement Identifier to be denied when an allow-list is set")
+	}
+}
+
+func TestScopedAuthorizerEnforceE(t *testing.T) {
+	scoped := initializeScopedAuthorizer()
+	subject := authz.WithScope(User{UserID: "user1"}, authz.Scope{Actions: []string{"read"}})
+
+	ok, err := scoped.EnforceE(subject, "delete", IdentifiedResource{ID: "res1", Owner: "user1"})
+	if err != nil || ok {
+		t.Errorf("EnforceE() on an out-of-scope action got (%v, %v), want (false, nil)", ok, err)
+	}
+}
+
+func TestScopedAuthorizerWildcardAction(t *testing.T) {
+	auth := authz.NewAuthorizer[User, IdentifiedResource]()
+	auth.AddPolicy("doc:read", func(User, IdentifiedResource) bool { return true })
+	scoped := authz.NewScopedAuthorizer(auth)
+	subject := authz.WithScope(User{UserID: "user1"}, authz.Scope{Actions: []string{"doc:*"}})
+
+	if !scoped.Enforce(subject, "doc:read", IdentifiedResource{ID: "res1"}) {
+		t.Error("expected a namespaced action to match a wildcard entry in Scope.Actions")
+	}
+}
+
+func TestScopedAuthorizerFilter(t *testing.T) {
+	scoped := initializeScopedAuthorizer()
+	subject := authz.WithScope(User{UserID: "user1"}, authz.Scope{
+		Actions:   []string{"read"},
+		AllowList: []string{"res1"},
+	})
+	resources := []IdentifiedResource{
+		{ID: "res1", Owner: "user2"},
+		{ID: "res2", Owner: "user2"},
+	}
+
+	got := scoped.Filter(subject, "read", resources)
+	if len(got) != 1 || got[0].ID != "res1" {
+		t.Errorf("Filter() = %v, want only res1", got)
+	}
+}